@@ -0,0 +1,237 @@
+package eventstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+)
+
+// ContentMode selects how a CloudEvent is encoded on the wire, per the
+// CloudEvents HTTP protocol binding spec.
+type ContentMode int
+
+const (
+	// ContentModeBinary carries the event's data as the HTTP body and its
+	// context attributes as ce-* headers. Only valid for a single event.
+	ContentModeBinary ContentMode = iota
+	// ContentModeStructured carries the whole event, context attributes
+	// and data alike, as a single application/cloudevents+json document.
+	// Only valid for a single event.
+	ContentModeStructured
+	// ContentModeBatched carries multiple structured-mode events as a
+	// JSON array with content type application/cloudevents-batch+json.
+	ContentModeBatched
+)
+
+// ToCloudEvent converts an Event to a validated cloudevents.Event, the type
+// used by github.com/cloudevents/sdk-go/v2 and the wider CloudEvents
+// ecosystem. Extensions round-trip as CloudEvents extension attributes.
+func (e Event) ToCloudEvent() (cloudevents.Event, error) {
+	specVersion := e.SpecVersion
+	if specVersion == "" {
+		specVersion = "1.0"
+	}
+	ce := cloudevents.NewEvent(specVersion)
+	ce.SetID(e.ID)
+	ce.SetSource(e.Source)
+	ce.SetType(e.Type)
+	if e.Subject != "" {
+		ce.SetSubject(e.Subject)
+	}
+	if e.DataSchema != "" {
+		ce.SetDataSchema(e.DataSchema)
+	}
+	if t := e.Time.Time(); !t.IsZero() {
+		ce.SetTime(t)
+	}
+	for name, value := range e.Extensions {
+		ce.SetExtension(name, value)
+	}
+
+	if e.Data != nil {
+		contentType := e.DataContentType
+		if contentType == "" {
+			contentType = "application/json"
+		}
+		if err := ce.SetData(contentType, e.Data); err != nil {
+			return cloudevents.Event{}, fmt.Errorf("error setting event data: %w", err)
+		}
+	}
+
+	if err := ce.Validate(); err != nil {
+		return cloudevents.Event{}, fmt.Errorf("invalid CloudEvent: %w", err)
+	}
+
+	return ce, nil
+}
+
+// EventFromCloudEvent converts a cloudevents.Event into an Event, decoding
+// its data payload and preserving unrecognized context attributes as
+// Extensions.
+func EventFromCloudEvent(ce cloudevents.Event) (Event, error) {
+	event := Event{
+		ID:              ce.ID(),
+		Source:          ce.Source(),
+		Subject:         ce.Subject(),
+		Type:            ce.Type(),
+		DataContentType: ce.DataContentType(),
+		DataSchema:      ce.DataSchema(),
+		SpecVersion:     ce.SpecVersion(),
+	}
+	if t := ce.Time(); !t.IsZero() {
+		event.Time = RFC3339Time(t)
+	}
+
+	if len(ce.Data()) > 0 {
+		var data interface{}
+		if err := ce.DataAs(&data); err != nil {
+			return Event{}, fmt.Errorf("error decoding event data: %w", err)
+		}
+		event.Data = data
+	}
+
+	if ext := ce.Extensions(); len(ext) > 0 {
+		event.Extensions = make(map[string]interface{}, len(ext))
+		for name, value := range ext {
+			event.Extensions[name] = value
+		}
+	}
+
+	return event, nil
+}
+
+// SendEvent sends a single, already-built and valid cloudevents.Event using
+// the given content mode.
+func (es *EventStore) SendEvent(ctx context.Context, ce cloudevents.Event, mode ContentMode) error {
+	if err := ce.Validate(); err != nil {
+		return fmt.Errorf("invalid CloudEvent: %w", err)
+	}
+	return es.sendCloudEvents(ctx, []cloudevents.Event{ce}, mode)
+}
+
+// SendEvents sends a batch of already-built and valid cloudevents.Event
+// values. ContentModeBinary is undefined for batches by the CloudEvents HTTP
+// binding spec, so mode must be ContentModeBatched (or ContentModeStructured
+// for a single-element batch).
+func (es *EventStore) SendEvents(ctx context.Context, ces []cloudevents.Event, mode ContentMode) error {
+	for _, ce := range ces {
+		if err := ce.Validate(); err != nil {
+			return fmt.Errorf("invalid CloudEvent %q: %w", ce.ID(), err)
+		}
+	}
+	return es.sendCloudEvents(ctx, ces, mode)
+}
+
+// StreamCloudEventsContext streams events from the specified subject,
+// returning them as cloudevents.Event values instead of the SDK's own Event
+// type.
+func (es *EventStore) StreamCloudEventsContext(ctx context.Context, subject string) ([]cloudevents.Event, error) {
+	events, err := es.StreamEventsContext(ctx, subject)
+	if err != nil {
+		return nil, err
+	}
+
+	ces := make([]cloudevents.Event, 0, len(events))
+	for _, event := range events {
+		ce, err := event.ToCloudEvent()
+		if err != nil {
+			return nil, err
+		}
+		ces = append(ces, ce)
+	}
+	return ces, nil
+}
+
+func (es *EventStore) sendCloudEvents(ctx context.Context, ces []cloudevents.Event, mode ContentMode) error {
+	url := fmt.Sprintf("%s/api/%s/commit", strings.TrimRight(es.config.APIURL, "/"), es.config.APIVersion)
+
+	switch mode {
+	case ContentModeBinary:
+		if len(ces) != 1 {
+			return fmt.Errorf("binary content mode requires exactly one event")
+		}
+		return es.sendBinary(ctx, url, ces[0])
+	case ContentModeStructured:
+		if len(ces) != 1 {
+			return fmt.Errorf("structured content mode requires exactly one event; use ContentModeBatched for more")
+		}
+		return es.sendStructured(ctx, url, ces[0])
+	case ContentModeBatched:
+		return es.sendBatched(ctx, url, ces)
+	default:
+		return fmt.Errorf("unknown content mode %d", mode)
+	}
+}
+
+func (es *EventStore) sendStructured(ctx context.Context, url string, ce cloudevents.Event) error {
+	body, err := json.Marshal(ce)
+	if err != nil {
+		return fmt.Errorf("error marshaling structured CloudEvent: %w", err)
+	}
+
+	resp, _, err := es.doCommit(ctx, "POST", url, body, map[string]string{
+		"Content-Type": "application/cloudevents+json",
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+func (es *EventStore) sendBatched(ctx context.Context, url string, ces []cloudevents.Event) error {
+	body, err := json.Marshal(ces)
+	if err != nil {
+		return fmt.Errorf("error marshaling CloudEvents batch: %w", err)
+	}
+
+	resp, _, err := es.doCommit(ctx, "POST", url, body, map[string]string{
+		"Content-Type": "application/cloudevents-batch+json",
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+func (es *EventStore) sendBinary(ctx context.Context, url string, ce cloudevents.Event) error {
+	contentType := ce.DataContentType()
+	if contentType == "" {
+		contentType = "application/json"
+	}
+
+	headers := map[string]string{
+		"Content-Type":   contentType,
+		"ce-id":          ce.ID(),
+		"ce-source":      ce.Source(),
+		"ce-specversion": ce.SpecVersion(),
+		"ce-type":        ce.Type(),
+	}
+	if subject := ce.Subject(); subject != "" {
+		headers["ce-subject"] = subject
+	}
+	if dataSchema := ce.DataSchema(); dataSchema != "" {
+		headers["ce-dataschema"] = dataSchema
+	}
+	if t := ce.Time(); !t.IsZero() {
+		headers["ce-time"] = t.Format(time.RFC3339)
+	}
+	for name, value := range ce.Extensions() {
+		headers["ce-"+name] = fmt.Sprintf("%v", value)
+	}
+
+	resp, _, err := es.doCommit(ctx, "POST", url, ce.Data(), headers)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
@@ -0,0 +1,116 @@
+package eventstore
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// apiErrorBody is the JSON error envelope the EventStore API returns
+// alongside non-2xx responses.
+type apiErrorBody struct {
+	Message string            `json:"message"`
+	Fields  map[string]string `json:"fields,omitempty"`
+}
+
+// ErrConflict indicates the server rejected a commit because it conflicts
+// with the subject's current state, without more specific precondition
+// detail (see ErrPreconditionFailed for preconditions that name what was
+// expected).
+type ErrConflict struct {
+	Subject string
+	Message string
+}
+
+func (e *ErrConflict) Error() string {
+	return fmt.Sprintf("conflict committing to subject %q: %s", e.Subject, e.Message)
+}
+
+// ErrPreconditionFailed indicates a Precondition passed to
+// CommitWithPreconditions did not hold.
+type ErrPreconditionFailed struct {
+	Subject string
+	Message string
+}
+
+func (e *ErrPreconditionFailed) Error() string {
+	return fmt.Sprintf("precondition failed for subject %q: %s", e.Subject, e.Message)
+}
+
+// ErrUnauthorized indicates the request's AuthToken was missing or rejected.
+type ErrUnauthorized struct {
+	Message string
+}
+
+func (e *ErrUnauthorized) Error() string {
+	return fmt.Sprintf("unauthorized: %s", e.Message)
+}
+
+// ErrNotFound indicates the requested subject or resource does not exist.
+type ErrNotFound struct {
+	Message string
+}
+
+func (e *ErrNotFound) Error() string {
+	return fmt.Sprintf("not found: %s", e.Message)
+}
+
+// ErrRateLimited indicates the request was throttled. RetryAfter is the
+// duration the server asked the caller to wait before retrying, parsed from
+// the Retry-After header; it is zero if the server didn't send one.
+type ErrRateLimited struct {
+	RetryAfter time.Duration
+	Message    string
+}
+
+func (e *ErrRateLimited) Error() string {
+	return fmt.Sprintf("rate limited, retry after %s: %s", e.RetryAfter, e.Message)
+}
+
+// ErrValidation indicates the request body failed server-side validation.
+// Fields maps field names to their validation error message, when the API
+// provided that detail.
+type ErrValidation struct {
+	Fields  map[string]string
+	Message string
+}
+
+func (e *ErrValidation) Error() string {
+	if len(e.Fields) == 0 {
+		return fmt.Sprintf("validation error: %s", e.Message)
+	}
+	return fmt.Sprintf("validation error: %s (fields: %v)", e.Message, e.Fields)
+}
+
+// parseAPIError turns a terminal non-2xx response into a typed error so
+// callers can use errors.As to react to specific failure modes. subject is
+// the event subject involved in the request, used to annotate conflict and
+// precondition errors; pass "" if the request wasn't subject-scoped.
+func parseAPIError(subject string) func(resp *http.Response, bodyBytes []byte) error {
+	return func(resp *http.Response, bodyBytes []byte) error {
+		var body apiErrorBody
+		_ = json.Unmarshal(bodyBytes, &body)
+		if body.Message == "" {
+			body.Message = string(bodyBytes)
+		}
+
+		switch resp.StatusCode {
+		case http.StatusUnauthorized, http.StatusForbidden:
+			return &ErrUnauthorized{Message: body.Message}
+		case http.StatusNotFound:
+			return &ErrNotFound{Message: body.Message}
+		case http.StatusConflict:
+			return &ErrConflict{Subject: subject, Message: body.Message}
+		case http.StatusPreconditionFailed:
+			return &ErrPreconditionFailed{Subject: subject, Message: body.Message}
+		case http.StatusTooManyRequests:
+			retryAfter, _ := retryAfterDelay(resp.Header.Get("Retry-After"))
+			return &ErrRateLimited{RetryAfter: retryAfter, Message: body.Message}
+		case http.StatusBadRequest, http.StatusUnprocessableEntity:
+			return &ErrValidation{Fields: body.Fields, Message: body.Message}
+		default:
+			return defaultParseAPIError(resp, bodyBytes)
+		}
+	}
+}
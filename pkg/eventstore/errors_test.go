@@ -0,0 +1,85 @@
+package eventstore
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestParseAPIErrorStatusMapping(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		wantTarget interface{ Error() string }
+	}{
+		{name: "unauthorized", statusCode: http.StatusUnauthorized, wantTarget: &ErrUnauthorized{}},
+		{name: "forbidden maps to unauthorized", statusCode: http.StatusForbidden, wantTarget: &ErrUnauthorized{}},
+		{name: "not found", statusCode: http.StatusNotFound, wantTarget: &ErrNotFound{}},
+		{name: "conflict", statusCode: http.StatusConflict, wantTarget: &ErrConflict{}},
+		{name: "precondition failed", statusCode: http.StatusPreconditionFailed, wantTarget: &ErrPreconditionFailed{}},
+		{name: "too many requests", statusCode: http.StatusTooManyRequests, wantTarget: &ErrRateLimited{}},
+		{name: "bad request maps to validation", statusCode: http.StatusBadRequest, wantTarget: &ErrValidation{}},
+		{name: "unprocessable entity maps to validation", statusCode: http.StatusUnprocessableEntity, wantTarget: &ErrValidation{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{
+				StatusCode: tt.statusCode,
+				Status:     http.StatusText(tt.statusCode),
+				Header:     http.Header{},
+			}
+			err := parseAPIError("orders-123")(resp, []byte(`{"message":"boom"}`))
+
+			target := reflect.New(reflect.TypeOf(tt.wantTarget))
+			if !errors.As(err, target.Interface()) {
+				t.Fatalf("parseAPIError status %d = %T, want errors.As match for %T", tt.statusCode, err, tt.wantTarget)
+			}
+		})
+	}
+}
+
+func TestParseAPIErrorDefaultsToGenericError(t *testing.T) {
+	resp := &http.Response{StatusCode: http.StatusTeapot, Status: "418 I'm a teapot"}
+	err := parseAPIError("")(resp, []byte("short and stout"))
+
+	var typed *ErrValidation
+	if errors.As(err, &typed) {
+		t.Fatalf("parseAPIError status 418 should not map to a typed error, got %T", err)
+	}
+	if err == nil {
+		t.Fatal("parseAPIError returned nil error")
+	}
+}
+
+func TestParseAPIErrorRateLimitedReadsRetryAfter(t *testing.T) {
+	rec := httptest.NewRecorder()
+	rec.Header().Set("Retry-After", "2")
+	rec.WriteHeader(http.StatusTooManyRequests)
+	resp := rec.Result()
+
+	err := parseAPIError("")(resp, []byte(`{"message":"slow down"}`))
+
+	var rateLimited *ErrRateLimited
+	if !errors.As(err, &rateLimited) {
+		t.Fatalf("parseAPIError(429) = %T, want *ErrRateLimited", err)
+	}
+	if rateLimited.RetryAfter.Seconds() != 2 {
+		t.Fatalf("RetryAfter = %v, want 2s", rateLimited.RetryAfter)
+	}
+}
+
+func TestParseAPIErrorFallsBackToRawBody(t *testing.T) {
+	resp := &http.Response{StatusCode: http.StatusNotFound}
+	err := parseAPIError("")(resp, []byte("no such subject"))
+
+	var notFound *ErrNotFound
+	if !errors.As(err, &notFound) {
+		t.Fatalf("parseAPIError(404) = %T, want *ErrNotFound", err)
+	}
+	if notFound.Message != "no such subject" {
+		t.Fatalf("Message = %q, want raw body when the body isn't the JSON envelope", notFound.Message)
+	}
+}
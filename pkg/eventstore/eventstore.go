@@ -1,8 +1,8 @@
 package eventstore
 
 import (
-	"bufio"
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -18,12 +18,40 @@ type Config struct {
 	APIURL     string
 	APIVersion string
 	AuthToken  string
+
+	// RetryPolicy controls retry/backoff behavior for the read-only
+	// *Context methods (stream, query, ping, audit). Commits are never
+	// retried. If nil, DefaultRetryPolicy() is used.
+	RetryPolicy *RetryPolicy
+
+	// HTTPClient is the http.Client used to make requests. If nil, a
+	// default client is used. Set this to inject a custom transport, for
+	// example one with mTLS or an OpenTelemetry-instrumented
+	// http.RoundTripper.
+	HTTPClient *http.Client
+	// Logger receives structured debug/info/warn/error logs for SDK
+	// operations. If nil, logging is a no-op.
+	Logger Logger
+	// Tracer opens a span around each SDK operation. If nil, tracing is a
+	// no-op.
+	Tracer Tracer
+	// Metrics receives counters, latency histograms, and gauges for SDK
+	// operations. If nil, metrics collection is a no-op.
+	Metrics Metrics
+
+	// PreferWebSocket makes Subscribe use a WebSocket transport instead of
+	// long-lived NDJSON tailing over HTTP.
+	PreferWebSocket bool
 }
 
 // EventStore represents the client for interacting with the EventStore API
 type EventStore struct {
-	config   *Config
-	client   *http.Client
+	config *Config
+	client *http.Client
+
+	// inFlightStreams tracks the number of open StreamEventsIter/QIter
+	// iterators, reported on the eventstore.streams_in_flight gauge.
+	inFlightStreams int64
 }
 
 // RFC3339Time is a custom time type that properly handles RFC3339 time strings
@@ -59,16 +87,25 @@ func (t RFC3339Time) Time() time.Time {
 	return time.Time(t)
 }
 
-// Event represents an event in the EventStore
+// Event represents an event in the EventStore. Its fields mirror the
+// CloudEvents v1.0 context attributes; see ToCloudEvent and
+// EventFromCloudEvent for conversion to/from cloudevents.Event.
 type Event struct {
-	ID              string                 `json:"id,omitempty"`
-	Source          string                 `json:"source,omitempty"`
-	Subject         string                 `json:"subject"`
-	Type            string                 `json:"type"`
-	Time            RFC3339Time            `json:"time,omitempty"`
-	Data            interface{}            `json:"data"`
-	DataContentType string                 `json:"datacontenttype,omitempty"`
-	SpecVersion     string                 `json:"specversion,omitempty"`
+	ID              string      `json:"id,omitempty"`
+	Source          string      `json:"source,omitempty"`
+	Subject         string      `json:"subject"`
+	Type            string      `json:"type"`
+	Time            RFC3339Time `json:"time,omitempty"`
+	Data            interface{} `json:"data"`
+	DataContentType string      `json:"datacontenttype,omitempty"`
+	DataSchema      string      `json:"dataschema,omitempty"`
+	SpecVersion     string      `json:"specversion,omitempty"`
+
+	// Extensions holds CloudEvents extension attributes that aren't
+	// represented by one of the fields above. They round-trip through
+	// ToCloudEvent/EventFromCloudEvent but are not part of this struct's
+	// own JSON encoding.
+	Extensions map[string]interface{} `json:"-"`
 }
 
 // NewEventStore creates a new EventStore client
@@ -83,85 +120,224 @@ func NewEventStore(config *Config) (*EventStore, error) {
 		return nil, fmt.Errorf("AuthToken is required")
 	}
 
+	client := config.HTTPClient
+	if client == nil {
+		client = &http.Client{}
+	}
+
 	return &EventStore{
 		config: config,
-		client: &http.Client{},
+		client: client,
 	}, nil
 }
 
-// StreamEvents streams events from the specified subject
-func (es *EventStore) StreamEvents(subject string) ([]Event, error) {
-	url := fmt.Sprintf("%s/api/%s/stream", strings.TrimRight(es.config.APIURL, "/"), es.config.APIVersion)
-
-	requestBody, err := json.Marshal(map[string]string{"subject": subject})
-	if err != nil {
-		return nil, fmt.Errorf("error marshaling request: %w", err)
+// retryPolicy returns the configured retry policy, falling back to
+// DefaultRetryPolicy() when none was set.
+func (es *EventStore) retryPolicy() *RetryPolicy {
+	if es.config.RetryPolicy != nil {
+		return es.config.RetryPolicy
 	}
+	return DefaultRetryPolicy()
+}
 
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(requestBody))
-	if err != nil {
-		return nil, fmt.Errorf("error creating request: %w", err)
-	}
+// doWithRetry performs an HTTP request, rebuilding it from scratch on each
+// attempt so the body can be resent, and retries transient failures
+// according to the client's retry policy. It returns the response with a
+// status code in the 2xx range, plus that same status code for callers that
+// want to record it (e.g. on a span); non-2xx responses that aren't retried
+// (or that exhaust retries) are turned into an error via the default
+// (string-formatted) error parser, and the status code returned alongside
+// that error is the last one observed, or 0 if no response was ever
+// received. It is only safe for idempotent requests (GET, or a POST whose
+// body is just a read); non-idempotent writes such as commits must use
+// doCommit or doWithRetryErr with idempotent set to false, since a retried
+// 5xx/429 response for a commit may have actually succeeded server-side.
+func (es *EventStore) doWithRetry(ctx context.Context, method, url string, body []byte, headers map[string]string) (*http.Response, int, error) {
+	return es.doWithRetryErr(ctx, method, url, body, headers, true, defaultParseAPIError)
+}
 
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", es.config.AuthToken))
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/x-ndjson")
-	req.Header.Set("User-Agent", "inoovum-eventstore-sdk-go")
+// doCommit performs a non-idempotent write request (a commit) with no
+// retries: a transport-level error doesn't prove the request never reached
+// the server (it may have failed while or after the server processed it),
+// and a retryable response status doesn't either. Either way, blindly
+// re-POSTing risks duplicate events (or, under CommitWithPreconditions, a
+// spurious precondition failure against the writer's own first attempt), so
+// a commit gets exactly one attempt and the caller decides whether to retry.
+func (es *EventStore) doCommit(ctx context.Context, method, url string, body []byte, headers map[string]string) (*http.Response, int, error) {
+	return es.doWithRetryErr(ctx, method, url, body, headers, false, defaultParseAPIError)
+}
 
-	resp, err := es.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("error making request: %w", err)
-	}
-	defer resp.Body.Close()
+// defaultParseAPIError is the doWithRetry error parser used by every SDK
+// method that predates the typed error taxonomy in errors.go.
+func defaultParseAPIError(resp *http.Response, bodyBytes []byte) error {
+	return fmt.Errorf("API error: %s - %s", resp.Status, string(bodyBytes))
+}
 
-	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API error: %s - %s", resp.Status, string(bodyBytes))
+// doWithRetryErr is doWithRetry with a pluggable parser for turning a
+// terminal non-2xx response into an error, and an explicit idempotent flag.
+// When idempotent is false (a commit), the first failure of any kind - a
+// transport-level error or a retryable response status - is returned
+// immediately without retrying, since neither proves the request never
+// reached the server. The returned status code is the last one observed (0
+// if the request never got a response at all).
+func (es *EventStore) doWithRetryErr(ctx context.Context, method, url string, body []byte, headers map[string]string, idempotent bool, parseErr func(*http.Response, []byte) error) (*http.Response, int, error) {
+	policy := es.retryPolicy()
+	maxAttempts := policy.MaxAttempts
+	if maxAttempts < 1 {
+		maxAttempts = 1
 	}
 
-	var events []Event
-	scanner := bufio.NewScanner(resp.Body)
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		if line == "" {
-			continue
+	var lastErr error
+	var lastStatus int
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		var bodyReader io.Reader
+		if body != nil {
+			bodyReader = bytes.NewReader(body)
 		}
 
-		var event Event
-		if err := json.Unmarshal([]byte(line), &event); err != nil {
-			return nil, fmt.Errorf("error parsing event JSON: %w", err)
+		req, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
+		if err != nil {
+			return nil, 0, fmt.Errorf("error creating request: %w", err)
 		}
 
-		// Set default values for CloudEvents compliance if not present
-		if event.ID == "" {
-			event.ID = uuid.New().String()
-		}
-		if event.Source == "" {
-			event.Source = es.config.APIURL
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", es.config.AuthToken))
+		req.Header.Set("User-Agent", "inoovum-eventstore-sdk-go")
+		for k, v := range headers {
+			req.Header.Set(k, v)
 		}
-		if event.DataContentType == "" {
-			event.DataContentType = "application/json"
+
+		resp, err := es.client.Do(req)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, lastStatus, ctx.Err()
+			}
+			lastErr = fmt.Errorf("error making request: %w", err)
+			if !idempotent {
+				return nil, lastStatus, lastErr
+			}
+		} else if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			lastStatus = resp.StatusCode
+			bodyBytes, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			lastErr = parseErr(resp, bodyBytes)
+
+			if !idempotent || !policy.isRetryable(resp.StatusCode) || attempt == maxAttempts {
+				return nil, lastStatus, lastErr
+			}
+
+			delay, ok := retryAfterDelay(resp.Header.Get("Retry-After"))
+			if !ok {
+				delay = policy.backoff(attempt)
+			}
+			if err := sleepContext(ctx, delay); err != nil {
+				return nil, lastStatus, err
+			}
+			continue
+		} else {
+			return resp, resp.StatusCode, nil
 		}
-		if event.SpecVersion == "" {
-			event.SpecVersion = "1.0"
+
+		if attempt == maxAttempts {
+			return nil, lastStatus, lastErr
 		}
-		if event.Time == RFC3339Time(time.Time{}) {
-			now := time.Now().UTC()
-			event.Time = RFC3339Time(now)
+		if err := sleepContext(ctx, policy.backoff(attempt)); err != nil {
+			return nil, lastStatus, err
 		}
+	}
 
-		events = append(events, event)
+	return nil, lastStatus, lastErr
+}
+
+// sleepContext waits for d, returning early with ctx.Err() if ctx is
+// canceled first.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// contextReader wraps an io.Reader and refuses further reads once ctx is
+// done, so a bufio.Scanner loop over a long-lived response body stops at its
+// next Read after the caller's deadline/cancellation fires. It does not
+// interrupt a Read that is already blocked; that is done by building the
+// request itself with the same ctx, which closes the connection.
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func newContextReader(ctx context.Context, r io.Reader) io.Reader {
+	return &contextReader{ctx: ctx, r: r}
+}
+
+func (cr *contextReader) Read(p []byte) (int, error) {
+	if err := cr.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return cr.r.Read(p)
+}
+
+// StreamEvents streams events from the specified subject
+func (es *EventStore) StreamEvents(subject string) ([]Event, error) {
+	return es.StreamEventsContext(context.Background(), subject)
+}
+
+// StreamEventsContext streams events from the specified subject. It honors
+// ctx cancellation and deadlines, and retries transient failures according
+// to es.config.RetryPolicy. It buffers the whole response in memory; for
+// large subjects use StreamEventsIter instead.
+func (es *EventStore) StreamEventsContext(ctx context.Context, subject string) (events []Event, err error) {
+	var statusCode int
+	ctx, finish, correlationID := es.startSpan(ctx, "eventstore.Stream", "subject", subject)
+	defer func() { finish(err, statusCode) }()
+
+	it, err := es.StreamEventsIter(ctx, subject)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+	if ei, ok := it.(*eventIterator); ok {
+		statusCode = ei.statusCode
 	}
 
-	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("error reading response: %w", err)
+	for {
+		var event Event
+		event, err = it.Next(ctx)
+		if err == io.EOF {
+			err = nil
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
 	}
 
+	es.logger().Debug("streamed events", "subject", subject, "count", len(events), "correlation_id", correlationID)
 	return events, nil
 }
 
 // CommitEvents commits a batch of events to the EventStore
 func (es *EventStore) CommitEvents(events []Event) error {
+	return es.CommitEventsContext(context.Background(), events)
+}
+
+// CommitEventsContext commits a batch of events to the EventStore. It honors
+// ctx cancellation and deadlines. It makes a single attempt and does not
+// retry, whatever es.config.RetryPolicy says (see doCommit).
+func (es *EventStore) CommitEventsContext(ctx context.Context, events []Event) (err error) {
+	var statusCode int
+	ctx, finish, correlationID := es.startSpan(ctx, "eventstore.Commit", "event_count", len(events))
+	defer func() { finish(err, statusCode) }()
+
 	url := fmt.Sprintf("%s/api/%s/commit", strings.TrimRight(es.config.APIURL, "/"), es.config.APIVersion)
 
 	// Ensure CloudEvents compliance for each event
@@ -189,104 +365,77 @@ func (es *EventStore) CommitEvents(events []Event) error {
 		return fmt.Errorf("error marshaling request: %w", err)
 	}
 
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(requestBody))
-	if err != nil {
-		return fmt.Errorf("error creating request: %w", err)
-	}
-
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", es.config.AuthToken))
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("User-Agent", "inoovum-eventstore-sdk-go")
-
-	resp, err := es.client.Do(req)
+	var resp *http.Response
+	resp, statusCode, err = es.doCommit(ctx, "POST", url, requestBody, map[string]string{
+		"Content-Type": "application/json",
+	})
 	if err != nil {
-		return fmt.Errorf("error making request: %w", err)
+		return err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("API error: %s - %s", resp.Status, string(bodyBytes))
-	}
-
+	es.metrics().IncCounter("eventstore.events_committed", int64(len(events)))
+	es.logger().Debug("committed events", "count", len(events), "correlation_id", correlationID)
 	return nil
 }
 
 // Q executes a query against the EventStore
 func (es *EventStore) Q(query string) ([]interface{}, error) {
-	url := fmt.Sprintf("%s/api/%s/q", strings.TrimRight(es.config.APIURL, "/"), es.config.APIVersion)
-
-	requestBody, err := json.Marshal(map[string]string{"query": query})
-	if err != nil {
-		return nil, fmt.Errorf("error marshaling request: %w", err)
-	}
-
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(requestBody))
-	if err != nil {
-		return nil, fmt.Errorf("error creating request: %w", err)
-	}
+	return es.QContext(context.Background(), query)
+}
 
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", es.config.AuthToken))
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/x-ndjson")
-	req.Header.Set("User-Agent", "inoovum-eventstore-sdk-go")
+// QContext executes a query against the EventStore. It honors ctx
+// cancellation and deadlines, and retries transient failures according to
+// es.config.RetryPolicy. It buffers the whole response in memory; for large
+// result sets use QIter instead.
+func (es *EventStore) QContext(ctx context.Context, query string) (results []interface{}, err error) {
+	var statusCode int
+	ctx, finish, correlationID := es.startSpan(ctx, "eventstore.Query", "query", query)
+	defer func() { finish(err, statusCode) }()
 
-	resp, err := es.client.Do(req)
+	it, err := es.QIter(ctx, query)
 	if err != nil {
-		return nil, fmt.Errorf("error making request: %w", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API error: %s - %s", resp.Status, string(bodyBytes))
+	defer it.Close()
+	if ri, ok := it.(*resultIterator); ok {
+		statusCode = ri.statusCode
 	}
 
-	var results []interface{}
-	scanner := bufio.NewScanner(resp.Body)
-	for scanner.Scan() {
-		line := strings.TrimSpace(scanner.Text())
-		if line == "" {
-			continue
-		}
-
+	for {
 		var result interface{}
-		if err := json.Unmarshal([]byte(line), &result); err != nil {
-			return nil, fmt.Errorf("error parsing result JSON: %w", err)
+		result, err = it.Next(ctx)
+		if err == io.EOF {
+			err = nil
+			break
+		}
+		if err != nil {
+			return nil, err
 		}
 		results = append(results, result)
 	}
 
-	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("error reading response: %w", err)
-	}
-
+	es.logger().Debug("queried results", "query", query, "count", len(results), "correlation_id", correlationID)
 	return results, nil
 }
 
 // Ping checks the health of the EventStore API
 func (es *EventStore) Ping() (string, error) {
-	url := fmt.Sprintf("%s/api/%s/status/ping", strings.TrimRight(es.config.APIURL, "/"), es.config.APIVersion)
-
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return "", fmt.Errorf("error creating request: %w", err)
-	}
+	return es.PingContext(context.Background())
+}
 
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", es.config.AuthToken))
-	req.Header.Set("User-Agent", "inoovum-eventstore-sdk-go")
+// PingContext checks the health of the EventStore API. It honors ctx
+// cancellation and deadlines and retries transient failures according to
+// es.config.RetryPolicy.
+func (es *EventStore) PingContext(ctx context.Context) (string, error) {
+	url := fmt.Sprintf("%s/api/%s/status/ping", strings.TrimRight(es.config.APIURL, "/"), es.config.APIVersion)
 
-	resp, err := es.client.Do(req)
+	resp, _, err := es.doWithRetry(ctx, "GET", url, nil, nil)
 	if err != nil {
-		return "", fmt.Errorf("error making request: %w", err)
+		return "", err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return "", fmt.Errorf("API error: %s - %s", resp.Status, string(bodyBytes))
-	}
-
 	bodyBytes, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return "", fmt.Errorf("error reading response: %w", err)
@@ -297,27 +446,21 @@ func (es *EventStore) Ping() (string, error) {
 
 // Audit runs an audit check on the EventStore
 func (es *EventStore) Audit() (string, error) {
-	url := fmt.Sprintf("%s/api/%s/status/audit", strings.TrimRight(es.config.APIURL, "/"), es.config.APIVersion)
-
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return "", fmt.Errorf("error creating request: %w", err)
-	}
+	return es.AuditContext(context.Background())
+}
 
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", es.config.AuthToken))
-	req.Header.Set("User-Agent", "inoovum-eventstore-sdk-go")
+// AuditContext runs an audit check on the EventStore. It honors ctx
+// cancellation and deadlines and retries transient failures according to
+// es.config.RetryPolicy.
+func (es *EventStore) AuditContext(ctx context.Context) (string, error) {
+	url := fmt.Sprintf("%s/api/%s/status/audit", strings.TrimRight(es.config.APIURL, "/"), es.config.APIVersion)
 
-	resp, err := es.client.Do(req)
+	resp, _, err := es.doWithRetry(ctx, "GET", url, nil, nil)
 	if err != nil {
-		return "", fmt.Errorf("error making request: %w", err)
+		return "", err
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		bodyBytes, _ := io.ReadAll(resp.Body)
-		return "", fmt.Errorf("API error: %s - %s", resp.Status, string(bodyBytes))
-	}
-
 	bodyBytes, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return "", fmt.Errorf("error reading response: %w", err)
@@ -0,0 +1,130 @@
+package eventstore
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Logger is the structured logging interface the client calls into. Pass an
+// adapter over your logging library of choice (e.g. zap, logrus, slog) via
+// Config.Logger. If nil, logging is a no-op.
+type Logger interface {
+	Debug(msg string, kv ...interface{})
+	Info(msg string, kv ...interface{})
+	Warn(msg string, kv ...interface{})
+	Error(msg string, kv ...interface{})
+}
+
+// Span represents a single unit of instrumented work, as returned by
+// Tracer.Start. It mirrors the subset of go.opentelemetry.io/otel/trace.Span
+// that the client needs, so an OpenTelemetry tracer can be adapted to it
+// without pulling the SDK in as a hard dependency.
+type Span interface {
+	SetAttributes(kv ...interface{})
+	RecordError(err error)
+	End()
+}
+
+// Tracer starts spans around SDK calls. Pass an adapter over
+// go.opentelemetry.io/otel/trace.Tracer (or any other tracer) via
+// Config.Tracer. If nil, tracing is a no-op.
+type Tracer interface {
+	Start(ctx context.Context, spanName string) (context.Context, Span)
+}
+
+// Metrics receives counters, histograms, and gauges for key SDK operations.
+// Pass an adapter over your metrics library of choice via Config.Metrics. If
+// nil, metrics collection is a no-op.
+type Metrics interface {
+	// IncCounter increments a named counter, e.g. "eventstore.events_committed".
+	IncCounter(name string, delta int64, tags ...string)
+	// ObserveLatency records a duration against a named histogram, e.g.
+	// "eventstore.request_latency".
+	ObserveLatency(name string, d time.Duration, tags ...string)
+	// SetGauge sets a named gauge, e.g. "eventstore.streams_in_flight".
+	SetGauge(name string, value float64, tags ...string)
+}
+
+type noopLogger struct{}
+
+func (noopLogger) Debug(string, ...interface{}) {}
+func (noopLogger) Info(string, ...interface{})  {}
+func (noopLogger) Warn(string, ...interface{})  {}
+func (noopLogger) Error(string, ...interface{}) {}
+
+type noopSpan struct{}
+
+func (noopSpan) SetAttributes(...interface{}) {}
+func (noopSpan) RecordError(error)            {}
+func (noopSpan) End()                         {}
+
+type noopTracer struct{}
+
+func (noopTracer) Start(ctx context.Context, _ string) (context.Context, Span) {
+	return ctx, noopSpan{}
+}
+
+type noopMetrics struct{}
+
+func (noopMetrics) IncCounter(string, int64, ...string)             {}
+func (noopMetrics) ObserveLatency(string, time.Duration, ...string) {}
+func (noopMetrics) SetGauge(string, float64, ...string)             {}
+
+func (es *EventStore) logger() Logger {
+	if es.config.Logger != nil {
+		return es.config.Logger
+	}
+	return noopLogger{}
+}
+
+func (es *EventStore) tracer() Tracer {
+	if es.config.Tracer != nil {
+		return es.config.Tracer
+	}
+	return noopTracer{}
+}
+
+func (es *EventStore) metrics() Metrics {
+	if es.config.Metrics != nil {
+		return es.config.Metrics
+	}
+	return noopMetrics{}
+}
+
+// adjustInFlightStreams atomically applies delta to the count of open
+// StreamEventsIter/QIter iterators and reports the new value on the
+// eventstore.streams_in_flight gauge.
+func (es *EventStore) adjustInFlightStreams(delta int64) {
+	n := atomic.AddInt64(&es.inFlightStreams, delta)
+	es.metrics().SetGauge("eventstore.streams_in_flight", float64(n))
+}
+
+// startSpan starts a span for an SDK operation and returns the derived
+// context, a finish func that records the elapsed latency on Metrics and the
+// error (if any) and, when statusCode is nonzero, the response's HTTP status
+// on the span, and a correlation ID unique to this call, for the caller to
+// attach to its own debug logs so they can be tied back to the span.
+func (es *EventStore) startSpan(ctx context.Context, name string, attrs ...interface{}) (context.Context, func(err error, statusCode int), string) {
+	ctx, span := es.tracer().Start(ctx, name)
+	if len(attrs) > 0 {
+		span.SetAttributes(attrs...)
+	}
+	correlationID := uuid.New().String()
+	span.SetAttributes("correlation_id", correlationID)
+	start := time.Now()
+
+	return ctx, func(err error, statusCode int) {
+		es.metrics().ObserveLatency("eventstore.request_latency", time.Since(start), "operation", name)
+		if statusCode != 0 {
+			span.SetAttributes("http_status", statusCode)
+		}
+		if err != nil {
+			span.RecordError(err)
+			es.logger().Error("eventstore request failed", "operation", name, "error", err, "correlation_id", correlationID, "http_status", statusCode)
+		}
+		span.End()
+	}, correlationID
+}
@@ -0,0 +1,188 @@
+package eventstore
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventIterator yields events from a streaming response one at a time
+// instead of buffering the whole NDJSON body in memory. Next returns
+// io.EOF once the stream is exhausted. Callers must call Close when done,
+// even if Next has already returned io.EOF.
+type EventIterator interface {
+	Next(ctx context.Context) (Event, error)
+	Close() error
+}
+
+// ResultIterator yields query results from a streaming response one at a
+// time. Next returns io.EOF once the stream is exhausted. Callers must call
+// Close when done, even if Next has already returned io.EOF.
+type ResultIterator interface {
+	Next(ctx context.Context) (interface{}, error)
+	Close() error
+}
+
+// eventIterator is the EventIterator implementation backed by an HTTP
+// response body scanned line by line.
+type eventIterator struct {
+	es         *EventStore
+	resp       *http.Response
+	scanner    *bufio.Scanner
+	statusCode int
+}
+
+func (it *eventIterator) Next(ctx context.Context) (Event, error) {
+	for {
+		if ctx.Err() != nil {
+			return Event{}, ctx.Err()
+		}
+		if !it.scanner.Scan() {
+			if err := it.scanner.Err(); err != nil {
+				if ctx.Err() != nil {
+					return Event{}, ctx.Err()
+				}
+				return Event{}, fmt.Errorf("error reading response: %w", err)
+			}
+			return Event{}, io.EOF
+		}
+
+		line := strings.TrimSpace(it.scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		var event Event
+		if err := json.Unmarshal([]byte(line), &event); err != nil {
+			return Event{}, fmt.Errorf("error parsing event JSON: %w", err)
+		}
+
+		if event.ID == "" {
+			event.ID = uuid.New().String()
+		}
+		if event.Source == "" {
+			event.Source = it.es.config.APIURL
+		}
+		if event.DataContentType == "" {
+			event.DataContentType = "application/json"
+		}
+		if event.SpecVersion == "" {
+			event.SpecVersion = "1.0"
+		}
+		if event.Time == RFC3339Time(time.Time{}) {
+			event.Time = RFC3339Time(time.Now().UTC())
+		}
+
+		it.es.metrics().IncCounter("eventstore.events_streamed", 1)
+		return event, nil
+	}
+}
+
+func (it *eventIterator) Close() error {
+	it.es.adjustInFlightStreams(-1)
+	return it.resp.Body.Close()
+}
+
+// resultIterator is the ResultIterator implementation backed by an HTTP
+// response body scanned line by line.
+type resultIterator struct {
+	es         *EventStore
+	resp       *http.Response
+	scanner    *bufio.Scanner
+	statusCode int
+}
+
+func (it *resultIterator) Next(ctx context.Context) (interface{}, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if !it.scanner.Scan() {
+		if err := it.scanner.Err(); err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			return nil, fmt.Errorf("error reading response: %w", err)
+		}
+		return nil, io.EOF
+	}
+
+	line := strings.TrimSpace(it.scanner.Text())
+	if line == "" {
+		return it.Next(ctx)
+	}
+
+	var result interface{}
+	if err := json.Unmarshal([]byte(line), &result); err != nil {
+		return nil, fmt.Errorf("error parsing result JSON: %w", err)
+	}
+
+	return result, nil
+}
+
+func (it *resultIterator) Close() error {
+	it.es.adjustInFlightStreams(-1)
+	return it.resp.Body.Close()
+}
+
+// StreamEventsIter streams events from the specified subject, yielding them
+// one at a time via the returned EventIterator instead of buffering the
+// whole response. The caller must Close the iterator when done.
+func (es *EventStore) StreamEventsIter(ctx context.Context, subject string) (EventIterator, error) {
+	url := fmt.Sprintf("%s/api/%s/stream", strings.TrimRight(es.config.APIURL, "/"), es.config.APIVersion)
+
+	requestBody, err := json.Marshal(map[string]string{"subject": subject})
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling request: %w", err)
+	}
+
+	resp, statusCode, err := es.doWithRetry(ctx, "POST", url, requestBody, map[string]string{
+		"Content-Type": "application/json",
+		"Accept":       "application/x-ndjson",
+	})
+	if err != nil {
+		return nil, err
+	}
+	es.adjustInFlightStreams(1)
+
+	return &eventIterator{
+		es:         es,
+		resp:       resp,
+		scanner:    bufio.NewScanner(newContextReader(ctx, resp.Body)),
+		statusCode: statusCode,
+	}, nil
+}
+
+// QIter executes a query against the EventStore, yielding results one at a
+// time via the returned ResultIterator instead of buffering the whole
+// response. The caller must Close the iterator when done.
+func (es *EventStore) QIter(ctx context.Context, query string) (ResultIterator, error) {
+	url := fmt.Sprintf("%s/api/%s/q", strings.TrimRight(es.config.APIURL, "/"), es.config.APIVersion)
+
+	requestBody, err := json.Marshal(map[string]string{"query": query})
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling request: %w", err)
+	}
+
+	resp, statusCode, err := es.doWithRetry(ctx, "POST", url, requestBody, map[string]string{
+		"Content-Type": "application/json",
+		"Accept":       "application/x-ndjson",
+	})
+	if err != nil {
+		return nil, err
+	}
+	es.adjustInFlightStreams(1)
+
+	return &resultIterator{
+		es:         es,
+		resp:       resp,
+		scanner:    bufio.NewScanner(newContextReader(ctx, resp.Body)),
+		statusCode: statusCode,
+	}, nil
+}
@@ -0,0 +1,121 @@
+package eventstore
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newTestEventStore(t *testing.T, handler http.HandlerFunc) *EventStore {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	es, err := NewEventStore(&Config{
+		APIURL:     server.URL,
+		APIVersion: "v1",
+		AuthToken:  "test-token",
+		HTTPClient: server.Client(),
+	})
+	if err != nil {
+		t.Fatalf("NewEventStore() error = %v", err)
+	}
+	return es
+}
+
+func TestStreamEventsIterReturnsEOFAtEndOfStream(t *testing.T) {
+	es := newTestEventStore(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"id":"evt-1","subject":"s","type":"t"}` + "\n"))
+		_, _ = w.Write([]byte(`{"id":"evt-2","subject":"s","type":"t"}` + "\n"))
+	})
+
+	it, err := es.StreamEventsIter(context.Background(), "s")
+	if err != nil {
+		t.Fatalf("StreamEventsIter() error = %v", err)
+	}
+	defer it.Close()
+
+	var ids []string
+	for {
+		event, err := it.Next(context.Background())
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Next() error = %v", err)
+		}
+		ids = append(ids, event.ID)
+	}
+
+	if len(ids) != 2 || ids[0] != "evt-1" || ids[1] != "evt-2" {
+		t.Fatalf("ids = %v, want [evt-1 evt-2]", ids)
+	}
+}
+
+func TestStreamEventsIterNextReturnsContextCanceled(t *testing.T) {
+	blockUntil := make(chan struct{})
+	es := newTestEventStore(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"id":"evt-1","subject":"s","type":"t"}` + "\n"))
+		if f, ok := w.(http.Flusher); ok {
+			f.Flush()
+		}
+		<-blockUntil
+	})
+	defer close(blockUntil)
+
+	it, err := es.StreamEventsIter(context.Background(), "s")
+	if err != nil {
+		t.Fatalf("StreamEventsIter() error = %v", err)
+	}
+	defer it.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if _, err := it.Next(ctx); err != nil {
+		t.Fatalf("first Next() error = %v", err)
+	}
+
+	cancel()
+	if _, err := it.Next(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Next() after cancel = %v, want context.Canceled", err)
+	}
+}
+
+func TestQIterReturnsEOFAtEndOfStream(t *testing.T) {
+	es := newTestEventStore(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"count":1}` + "\n"))
+	})
+
+	it, err := es.QIter(context.Background(), "select *")
+	if err != nil {
+		t.Fatalf("QIter() error = %v", err)
+	}
+	defer it.Close()
+
+	if _, err := it.Next(context.Background()); err != nil {
+		t.Fatalf("first Next() error = %v", err)
+	}
+	if _, err := it.Next(context.Background()); err != io.EOF {
+		t.Fatalf("second Next() error = %v, want io.EOF", err)
+	}
+}
+
+func TestStreamEventsIterSurfacesNon2xxAsError(t *testing.T) {
+	es := newTestEventStore(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte("boom"))
+	})
+	es.config.RetryPolicy = &RetryPolicy{MaxAttempts: 1}
+
+	if _, err := es.StreamEventsIter(context.Background(), "s"); err == nil {
+		t.Fatal("StreamEventsIter() error = nil, want an error for a 500 response")
+	}
+}
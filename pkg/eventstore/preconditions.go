@@ -0,0 +1,143 @@
+package eventstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// preconditionKind identifies which optimistic-concurrency check a
+// Precondition expresses.
+type preconditionKind int
+
+const (
+	preconditionSubjectExists preconditionKind = iota
+	preconditionSubjectDoesNotExist
+	preconditionExpectedLastEventID
+	preconditionExpectedRevision
+)
+
+// Precondition expresses an optimistic-concurrency check the server must
+// satisfy before committing events to a subject. Construct one with
+// SubjectExists, SubjectDoesNotExist, ExpectedLastEventID, or
+// ExpectedRevision.
+type Precondition struct {
+	subject             string
+	kind                preconditionKind
+	expectedLastEventID string
+	expectedRevision    int64
+}
+
+// SubjectExists requires that subject already has at least one event
+// committed to it.
+func SubjectExists(subject string) Precondition {
+	return Precondition{subject: subject, kind: preconditionSubjectExists}
+}
+
+// SubjectDoesNotExist requires that subject has no events committed to it
+// yet, for creating a new aggregate without clobbering an existing one.
+func SubjectDoesNotExist(subject string) Precondition {
+	return Precondition{subject: subject, kind: preconditionSubjectDoesNotExist}
+}
+
+// ExpectedLastEventID requires that the last event committed to subject has
+// the given ID, so a writer that read a stale view of the subject is
+// rejected.
+func ExpectedLastEventID(subject, eventID string) Precondition {
+	return Precondition{subject: subject, kind: preconditionExpectedLastEventID, expectedLastEventID: eventID}
+}
+
+// ExpectedRevision requires that subject is currently at the given revision
+// (its committed event count).
+func ExpectedRevision(subject string, revision int64) Precondition {
+	return Precondition{subject: subject, kind: preconditionExpectedRevision, expectedRevision: revision}
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (p Precondition) MarshalJSON() ([]byte, error) {
+	wire := struct {
+		Subject             string `json:"subject"`
+		SubjectExists       *bool  `json:"subject_exists,omitempty"`
+		SubjectDoesNotExist *bool  `json:"subject_does_not_exist,omitempty"`
+		ExpectedLastEventID string `json:"expected_last_event_id,omitempty"`
+		ExpectedRevision    *int64 `json:"expected_revision,omitempty"`
+	}{Subject: p.subject}
+
+	truth := true
+	switch p.kind {
+	case preconditionSubjectExists:
+		wire.SubjectExists = &truth
+	case preconditionSubjectDoesNotExist:
+		wire.SubjectDoesNotExist = &truth
+	case preconditionExpectedLastEventID:
+		wire.ExpectedLastEventID = p.expectedLastEventID
+	case preconditionExpectedRevision:
+		wire.ExpectedRevision = &p.expectedRevision
+	default:
+		return nil, fmt.Errorf("unknown precondition kind %d", p.kind)
+	}
+
+	return json.Marshal(wire)
+}
+
+// CommitWithPreconditions commits a batch of events like CommitEvents, but
+// only if every precondition holds. This is the building block for
+// event-sourced aggregates that must reject concurrent writers: load a
+// subject, compute its next events, and commit them with an
+// ExpectedLastEventID (or ExpectedRevision) precondition so a concurrent
+// writer that got there first causes this call to fail instead of silently
+// overwriting.
+//
+// Responses with status 400, 401, 403, 404, 409, 412, 422 or 429 are
+// returned as one of the typed errors in errors.go (ErrPreconditionFailed,
+// ErrConflict, ErrValidation, ErrUnauthorized, ErrNotFound, ErrRateLimited)
+// so callers can react with errors.As; other statuses and transport
+// failures are returned as plain errors.
+func (es *EventStore) CommitWithPreconditions(ctx context.Context, events []Event, preconditions []Precondition) error {
+	url := fmt.Sprintf("%s/api/%s/commit", strings.TrimRight(es.config.APIURL, "/"), es.config.APIVersion)
+
+	for i := range events {
+		if events[i].ID == "" {
+			events[i].ID = uuid.New().String()
+		}
+		if events[i].Source == "" {
+			events[i].Source = es.config.APIURL
+		}
+		if events[i].DataContentType == "" {
+			events[i].DataContentType = "application/json"
+		}
+		if events[i].SpecVersion == "" {
+			events[i].SpecVersion = "1.0"
+		}
+		if events[i].Time == RFC3339Time(time.Time{}) {
+			events[i].Time = RFC3339Time(time.Now().UTC())
+		}
+	}
+
+	requestBody, err := json.Marshal(struct {
+		Events        []Event        `json:"events"`
+		Preconditions []Precondition `json:"preconditions"`
+	}{Events: events, Preconditions: preconditions})
+	if err != nil {
+		return fmt.Errorf("error marshaling request: %w", err)
+	}
+
+	subject := ""
+	if len(preconditions) > 0 {
+		subject = preconditions[0].subject
+	}
+
+	resp, _, err := es.doWithRetryErr(ctx, "POST", url, requestBody, map[string]string{
+		"Content-Type": "application/json",
+	}, false, parseAPIError(subject))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package eventstore
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestPreconditionMarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		cond Precondition
+		want string
+	}{
+		{
+			name: "subject exists",
+			cond: SubjectExists("orders-123"),
+			want: `{"subject":"orders-123","subject_exists":true}`,
+		},
+		{
+			name: "subject does not exist",
+			cond: SubjectDoesNotExist("orders-123"),
+			want: `{"subject":"orders-123","subject_does_not_exist":true}`,
+		},
+		{
+			name: "expected last event id",
+			cond: ExpectedLastEventID("orders-123", "evt-1"),
+			want: `{"subject":"orders-123","expected_last_event_id":"evt-1"}`,
+		},
+		{
+			name: "expected revision",
+			cond: ExpectedRevision("orders-123", 7),
+			want: `{"subject":"orders-123","expected_revision":7}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.cond)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Fatalf("Marshal() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPreconditionMarshalJSONUnknownKind(t *testing.T) {
+	cond := Precondition{subject: "orders-123", kind: preconditionKind(99)}
+	if _, err := json.Marshal(cond); err == nil {
+		t.Fatal("Marshal() with an unknown kind returned nil error, want an error")
+	}
+}
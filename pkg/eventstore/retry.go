@@ -0,0 +1,95 @@
+package eventstore
+
+import (
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how the client retries transient failures.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts (including the first),
+	// so MaxAttempts: 1 disables retries.
+	MaxAttempts int
+	// BaseDelay is the upper bound of the (jittered) delay before the first
+	// retry; the bound for subsequent retries grows exponentially from it.
+	BaseDelay time.Duration
+	// MaxDelay caps the computed backoff delay.
+	MaxDelay time.Duration
+	// RetryableStatusCodes lists HTTP status codes that should trigger a
+	// retry. If nil, DefaultRetryableStatusCodes is used.
+	RetryableStatusCodes map[int]bool
+}
+
+// DefaultRetryPolicy returns the retry policy used when Config.RetryPolicy
+// is left unset: three attempts, 200ms base backoff up to 5s.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:          3,
+		BaseDelay:            200 * time.Millisecond,
+		MaxDelay:             5 * time.Second,
+		RetryableStatusCodes: DefaultRetryableStatusCodes(),
+	}
+}
+
+// DefaultRetryableStatusCodes returns the status codes retried by default:
+// 429, 500, 502, 503 and 504.
+func DefaultRetryableStatusCodes() map[int]bool {
+	return map[int]bool{
+		http.StatusTooManyRequests:     true,
+		http.StatusInternalServerError: true,
+		http.StatusBadGateway:          true,
+		http.StatusServiceUnavailable:  true,
+		http.StatusGatewayTimeout:      true,
+	}
+}
+
+func (p *RetryPolicy) isRetryable(statusCode int) bool {
+	codes := p.RetryableStatusCodes
+	if codes == nil {
+		codes = DefaultRetryableStatusCodes()
+	}
+	return codes[statusCode]
+}
+
+// backoff computes the delay before the given retry attempt (1-based),
+// applying full jitter and capping at MaxDelay.
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.BaseDelay
+	if shift := uint(attempt - 1); shift > 0 && delay > 0 {
+		if shift >= 63 || delay > time.Duration(math.MaxInt64>>shift) {
+			delay = time.Duration(math.MaxInt64)
+		} else {
+			delay <<= shift
+		}
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(delay)))
+}
+
+// retryAfterDelay parses a Retry-After header value, supporting both the
+// delay-seconds and HTTP-date forms. It returns false if the header is
+// absent or unparsable.
+func retryAfterDelay(header string) (time.Duration, bool) {
+	if header == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(header); err == nil {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(header); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
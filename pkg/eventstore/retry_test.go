@@ -0,0 +1,90 @@
+package eventstore
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRetryAfterDelay(t *testing.T) {
+	tests := []struct {
+		name      string
+		header    string
+		wantDelay time.Duration
+		wantOK    bool
+	}{
+		{name: "empty header", header: "", wantOK: false},
+		{name: "delay seconds", header: "5", wantDelay: 5 * time.Second, wantOK: true},
+		{name: "zero seconds", header: "0", wantDelay: 0, wantOK: true},
+		{name: "unparsable garbage", header: "not-a-date", wantOK: false},
+		{
+			name:      "http date in the future",
+			header:    time.Now().Add(10 * time.Second).UTC().Format(http.TimeFormat),
+			wantDelay: 10 * time.Second,
+			wantOK:    true,
+		},
+		{
+			name:   "http date in the past clamps to zero",
+			header: time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat),
+			wantOK: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delay, ok := retryAfterDelay(tt.header)
+			if ok != tt.wantOK {
+				t.Fatalf("retryAfterDelay(%q) ok = %v, want %v", tt.header, ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			// The HTTP-date cases are computed relative to time.Now(), so
+			// allow a little slack for test execution time.
+			if diff := delay - tt.wantDelay; diff < -time.Second || diff > time.Second {
+				t.Fatalf("retryAfterDelay(%q) = %v, want ~%v", tt.header, delay, tt.wantDelay)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := &RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+
+	for attempt := 1; attempt <= 6; attempt++ {
+		delay := policy.backoff(attempt)
+		if delay < 0 || delay > policy.MaxDelay {
+			t.Fatalf("backoff(%d) = %v, want within [0, %v]", attempt, delay, policy.MaxDelay)
+		}
+	}
+}
+
+func TestRetryPolicyBackoffZeroBaseDelay(t *testing.T) {
+	policy := &RetryPolicy{}
+	if delay := policy.backoff(1); delay != 0 {
+		t.Fatalf("backoff(1) with zero BaseDelay = %v, want 0", delay)
+	}
+}
+
+func TestRetryPolicyIsRetryable(t *testing.T) {
+	policy := DefaultRetryPolicy()
+
+	for _, code := range []int{429, 500, 502, 503, 504} {
+		if !policy.isRetryable(code) {
+			t.Errorf("isRetryable(%d) = false, want true", code)
+		}
+	}
+	for _, code := range []int{200, 400, 401, 404, 409} {
+		if policy.isRetryable(code) {
+			t.Errorf("isRetryable(%d) = true, want false", code)
+		}
+	}
+
+	custom := &RetryPolicy{RetryableStatusCodes: map[int]bool{418: true}}
+	if !custom.isRetryable(418) {
+		t.Error("isRetryable(418) with custom codes = false, want true")
+	}
+	if custom.isRetryable(500) {
+		t.Error("isRetryable(500) with custom codes = true, want false")
+	}
+}
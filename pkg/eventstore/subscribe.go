@@ -0,0 +1,382 @@
+package eventstore
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+)
+
+// SubscribeOptions configures a long-lived subscription started with
+// Subscribe.
+type SubscribeOptions struct {
+	// Subject is the subject to subscribe to.
+	Subject string
+	// FromEventID resumes the subscription after the given event ID. If
+	// empty, the subscription starts from the current tail of the stream
+	// (or from the beginning if IncludeReplay is set).
+	FromEventID string
+	// IncludeReplay replays all events currently in the subject before
+	// switching to live delivery.
+	IncludeReplay bool
+	// HeartbeatInterval is the expected interval between server
+	// heartbeats (or WebSocket pings). If no activity is observed for
+	// roughly twice this interval, the connection is considered dead and
+	// reconnected. Defaults to 30s.
+	HeartbeatInterval time.Duration
+}
+
+// Subscription delivers events pushed by a long-lived subscription started
+// with Subscribe. Events and Errors are closed once the subscription is
+// closed, either by calling Close or because ctx was canceled.
+type Subscription interface {
+	Events() <-chan Event
+	Errors() <-chan error
+	Close() error
+}
+
+// subscription is the shared Subscription implementation for both the NDJSON
+// tailing and WebSocket transports.
+type subscription struct {
+	cancel context.CancelFunc
+	events chan Event
+	errs   chan error
+	done   chan struct{}
+	once   sync.Once
+}
+
+func newSubscription(cancel context.CancelFunc) *subscription {
+	return &subscription{
+		cancel: cancel,
+		events: make(chan Event, 64),
+		errs:   make(chan error, 1),
+		done:   make(chan struct{}),
+	}
+}
+
+func (s *subscription) Events() <-chan Event { return s.events }
+func (s *subscription) Errors() <-chan error { return s.errs }
+
+func (s *subscription) Close() error {
+	s.once.Do(func() {
+		s.cancel()
+		<-s.done
+	})
+	return nil
+}
+
+func (s *subscription) finish() {
+	close(s.events)
+	close(s.errs)
+	close(s.done)
+}
+
+// Subscribe opens a long-lived subscription to opts.Subject, delivering
+// events as they are committed instead of returning a single snapshot like
+// StreamEvents. By default it keeps an HTTP connection open reading NDJSON
+// continuously, reconnecting with the last-seen event ID as the resume point
+// on disconnect. If es.config.PreferWebSocket is set, it instead upgrades to
+// a WebSocket transport with ping/pong keepalives.
+func (es *EventStore) Subscribe(ctx context.Context, opts SubscribeOptions) (Subscription, error) {
+	if opts.Subject == "" {
+		return nil, fmt.Errorf("Subject is required")
+	}
+	if opts.HeartbeatInterval <= 0 {
+		opts.HeartbeatInterval = 30 * time.Second
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	sub := newSubscription(cancel)
+
+	if es.config.PreferWebSocket {
+		go es.runWebSocketSubscription(ctx, sub, opts)
+	} else {
+		go es.runHTTPSubscription(ctx, sub, opts)
+	}
+
+	return sub, nil
+}
+
+// runHTTPSubscription keeps an HTTP NDJSON connection open, reconnecting
+// with backoff from the last-seen event ID whenever it drops or goes idle
+// for longer than twice the heartbeat interval.
+func (es *EventStore) runHTTPSubscription(ctx context.Context, sub *subscription, opts SubscribeOptions) {
+	defer sub.finish()
+
+	fromEventID := opts.FromEventID
+	includeReplay := opts.IncludeReplay
+	attempt := 0
+
+	for ctx.Err() == nil {
+		lastEventID, err := es.tailHTTPSubscription(ctx, sub, opts.Subject, fromEventID, includeReplay, opts.HeartbeatInterval)
+		if lastEventID != "" {
+			fromEventID = lastEventID
+			// A successful reconnect should not replay already-delivered
+			// events again.
+			includeReplay = false
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			select {
+			case sub.errs <- err:
+			default:
+			}
+		}
+
+		attempt++
+		delay := DefaultRetryPolicy().backoff(attempt)
+		if err := sleepContext(ctx, delay); err != nil {
+			return
+		}
+	}
+}
+
+// tailHTTPSubscription opens a single subscribe request and streams events
+// from it until the connection drops, goes idle, or ctx is done. It returns
+// the last event ID seen, so the caller can resume from it.
+func (es *EventStore) tailHTTPSubscription(ctx context.Context, sub *subscription, subject, fromEventID string, includeReplay bool, heartbeatInterval time.Duration) (lastEventID string, err error) {
+	streamURL := fmt.Sprintf("%s/api/%s/subscribe", strings.TrimRight(es.config.APIURL, "/"), es.config.APIVersion)
+
+	requestBody, err := json.Marshal(map[string]interface{}{
+		"subject":        subject,
+		"from_event_id":  fromEventID,
+		"include_replay": includeReplay,
+	})
+	if err != nil {
+		return "", fmt.Errorf("error marshaling request: %w", err)
+	}
+
+	// idleCtx is canceled both by the parent ctx and by watchIdle once the
+	// connection goes quiet for too long. Building the request with idleCtx,
+	// rather than just wrapping resp.Body in a context-aware reader, lets
+	// canceling it make the http.Client close the underlying connection,
+	// which is what actually unblocks a Read stuck on a silent socket - the
+	// same effect SetReadDeadline has on the WebSocket transport below.
+	idleCtx, cancelIdle := context.WithCancel(ctx)
+	defer cancelIdle()
+
+	req, err := http.NewRequestWithContext(idleCtx, "POST", streamURL, bytes.NewReader(requestBody))
+	if err != nil {
+		return "", fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", es.config.AuthToken))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/x-ndjson")
+	req.Header.Set("User-Agent", "inoovum-eventstore-sdk-go")
+
+	resp, err := es.client.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+		return "", fmt.Errorf("error making request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("API error: %s", resp.Status)
+	}
+
+	activity := make(chan struct{})
+	go watchIdle(idleCtx, cancelIdle, activity, 2*heartbeatInterval)
+
+	scanner := bufio.NewScanner(newContextReader(idleCtx, resp.Body))
+	for scanner.Scan() {
+		select {
+		case activity <- struct{}{}:
+		default:
+		}
+
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		var event Event
+		if err := json.Unmarshal([]byte(line), &event); err != nil {
+			return lastEventID, fmt.Errorf("error parsing event JSON: %w", err)
+		}
+		serverEventID := event.ID
+		if event.ID == "" {
+			event.ID = uuid.New().String()
+		}
+		if event.Source == "" {
+			event.Source = es.config.APIURL
+		}
+
+		select {
+		case sub.events <- event:
+		case <-ctx.Done():
+			return lastEventID, ctx.Err()
+		}
+		// Only a server-assigned ID is a resume token the server will
+		// recognize; a client-generated fallback would come back as a
+		// from_event_id the server never issued.
+		if serverEventID != "" {
+			lastEventID = serverEventID
+		}
+	}
+
+	if err := scanner.Err(); err != nil && ctx.Err() == nil {
+		if idleCtx.Err() != nil {
+			return lastEventID, fmt.Errorf("subscription idle for too long: %w", idleCtx.Err())
+		}
+		return lastEventID, fmt.Errorf("error reading response: %w", err)
+	}
+	return lastEventID, nil
+}
+
+// watchIdle cancels cancel if no activity is observed on the activity
+// channel for longer than idleTimeout.
+func watchIdle(ctx context.Context, cancel context.CancelFunc, activity <-chan struct{}, idleTimeout time.Duration) {
+	timer := time.NewTimer(idleTimeout)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-activity:
+			if !timer.Stop() {
+				<-timer.C
+			}
+			timer.Reset(idleTimeout)
+		case <-timer.C:
+			cancel()
+			return
+		}
+	}
+}
+
+// runWebSocketSubscription keeps a WebSocket connection open, sending
+// keepalive pings and reconnecting with backoff from the last-seen event ID
+// whenever the connection drops.
+func (es *EventStore) runWebSocketSubscription(ctx context.Context, sub *subscription, opts SubscribeOptions) {
+	defer sub.finish()
+
+	fromEventID := opts.FromEventID
+	includeReplay := opts.IncludeReplay
+	attempt := 0
+
+	for ctx.Err() == nil {
+		lastEventID, err := es.tailWebSocketSubscription(ctx, sub, opts.Subject, fromEventID, includeReplay, opts.HeartbeatInterval)
+		if lastEventID != "" {
+			fromEventID = lastEventID
+			includeReplay = false
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			select {
+			case sub.errs <- err:
+			default:
+			}
+		}
+
+		attempt++
+		if err := sleepContext(ctx, DefaultRetryPolicy().backoff(attempt)); err != nil {
+			return
+		}
+	}
+}
+
+func (es *EventStore) tailWebSocketSubscription(ctx context.Context, sub *subscription, subject, fromEventID string, includeReplay bool, heartbeatInterval time.Duration) (lastEventID string, err error) {
+	wsURL := fmt.Sprintf("%s/api/%s/subscribe/ws", strings.TrimRight(es.config.APIURL, "/"), es.config.APIVersion)
+	wsURL = strings.Replace(wsURL, "https://", "wss://", 1)
+	wsURL = strings.Replace(wsURL, "http://", "ws://", 1)
+
+	query := url.Values{}
+	query.Set("subject", subject)
+	if fromEventID != "" {
+		query.Set("from_event_id", fromEventID)
+	}
+	if includeReplay {
+		query.Set("include_replay", "true")
+	}
+
+	dialer := &websocket.Dialer{
+		ReadBufferSize:   4096,
+		HandshakeTimeout: 10 * time.Second,
+	}
+	header := http.Header{}
+	header.Set("Authorization", fmt.Sprintf("Bearer %s", es.config.AuthToken))
+	header.Set("User-Agent", "inoovum-eventstore-sdk-go")
+
+	conn, resp, err := dialer.DialContext(ctx, wsURL+"?"+query.Encode(), header)
+	if err != nil {
+		if resp != nil {
+			return "", fmt.Errorf("error dialing subscription websocket: %s: %w", resp.Status, err)
+		}
+		return "", fmt.Errorf("error dialing subscription websocket: %w", err)
+	}
+	defer conn.Close()
+
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(2 * heartbeatInterval))
+	})
+	if err := conn.SetReadDeadline(time.Now().Add(2 * heartbeatInterval)); err != nil {
+		return "", err
+	}
+
+	stopPing := make(chan struct{})
+	defer close(stopPing)
+	go func() {
+		ticker := time.NewTicker(heartbeatInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stopPing:
+				return
+			case <-ticker.C:
+				_ = conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(5*time.Second))
+			}
+		}
+	}()
+
+	go func() {
+		<-ctx.Done()
+		_ = conn.Close()
+	}()
+
+	for {
+		_, message, readErr := conn.ReadMessage()
+		if readErr != nil {
+			if ctx.Err() != nil {
+				return lastEventID, ctx.Err()
+			}
+			return lastEventID, fmt.Errorf("error reading subscription websocket: %w", readErr)
+		}
+
+		var event Event
+		if err := json.Unmarshal(message, &event); err != nil {
+			return lastEventID, fmt.Errorf("error parsing event JSON: %w", err)
+		}
+		serverEventID := event.ID
+		if event.ID == "" {
+			event.ID = uuid.New().String()
+		}
+		if event.Source == "" {
+			event.Source = es.config.APIURL
+		}
+
+		select {
+		case sub.events <- event:
+		case <-ctx.Done():
+			return lastEventID, ctx.Err()
+		}
+		if serverEventID != "" {
+			lastEventID = serverEventID
+		}
+	}
+}